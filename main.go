@@ -1,23 +1,44 @@
 package main
 
 import (
+	"encoding/hex"
+	"fmt"
 	"log"
 	"os"
 	"path"
 	"time"
 
 	"github.com/keep-network/keep-tecdsa/cmd"
+	"github.com/keep-network/keep-tecdsa/pkg/chain/btc/broadcast"
+	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/preparams"
 	"github.com/urfave/cli"
 )
 
 const (
 	defaultConfigPath   = "./configs/config.toml"
-	defaultBroadcastAPI = "blockcypher"
+	defaultBroadcastAPI = broadcast.BlockCypher
+
+	defaultTSSPreParamsTargetSize  = 100
+	defaultTSSPreParamsConcurrency = 2
 )
 
 var (
 	configPath   string
 	broadcastAPI string
+
+	btcNetwork string
+
+	electrumServers cli.StringSlice
+	electrumUseTLS  bool
+
+	bitcoindRPCURL  string
+	bitcoindRPCUser string
+	bitcoindRPCPass string
+
+	tssPreParamsStore         string
+	tssPreParamsEncryptionKey string
+	tssPreParamsTargetSize    int
+	tssPreParamsConcurrency   int
 )
 
 func main() {
@@ -42,7 +63,64 @@ func main() {
 			Name:        "broadcast-api",
 			Value:       defaultBroadcastAPI,
 			Destination: &broadcastAPI,
-			Usage:       "external service used to communicate with the blockchain",
+			Usage: fmt.Sprintf(
+				"external service used to communicate with the blockchain, one of: [%s, %s, %s]",
+				broadcast.BlockCypher,
+				broadcast.Electrum,
+				broadcast.Bitcoind,
+			),
+		},
+		cli.StringFlag{
+			Name:        "btc-network",
+			Destination: &btcNetwork,
+			Usage:       "Bitcoin network to operate on, e.g. \"main\" or \"test3\"; used by the blockcypher broadcast-api",
+		},
+		cli.StringSliceFlag{
+			Name:  "electrum-server",
+			Value: &electrumServers,
+			Usage: "\"host:port\" of an Electrum server to try, in order; repeatable; used by the electrum broadcast-api",
+		},
+		cli.BoolFlag{
+			Name:        "electrum-tls",
+			Destination: &electrumUseTLS,
+			Usage:       "connect to electrum-servers over TLS; used by the electrum broadcast-api",
+		},
+		cli.StringFlag{
+			Name:        "bitcoind-rpc-url",
+			Destination: &bitcoindRPCURL,
+			Usage:       "JSON-RPC endpoint of a bitcoind-compatible node; used by the bitcoind broadcast-api",
+		},
+		cli.StringFlag{
+			Name:        "bitcoind-rpc-user",
+			Destination: &bitcoindRPCUser,
+			Usage:       "JSON-RPC username for bitcoind-rpc-url; used by the bitcoind broadcast-api",
+		},
+		cli.StringFlag{
+			Name:        "bitcoind-rpc-pass",
+			Destination: &bitcoindRPCPass,
+			Usage:       "JSON-RPC password for bitcoind-rpc-url; used by the bitcoind broadcast-api",
+		},
+		cli.StringFlag{
+			Name:        "tss-preparams-store",
+			Destination: &tssPreParamsStore,
+			Usage:       "directory to persist generated tss pre-params in; enables the background pre-params pool when set",
+		},
+		cli.StringFlag{
+			Name:        "tss-preparams-encryption-key",
+			Destination: &tssPreParamsEncryptionKey,
+			Usage:       "hex-encoded key used to encrypt pre-params persisted under tss-preparams-store",
+		},
+		cli.IntFlag{
+			Name:        "tss-preparams-target-size",
+			Value:       defaultTSSPreParamsTargetSize,
+			Destination: &tssPreParamsTargetSize,
+			Usage:       "number of generated tss pre-params to keep ready on hand at all times",
+		},
+		cli.IntFlag{
+			Name:        "tss-preparams-concurrency",
+			Value:       defaultTSSPreParamsConcurrency,
+			Destination: &tssPreParamsConcurrency,
+			Usage:       "number of tss pre-params generated in parallel in the background",
 		},
 	}
 	app.Commands = []cli.Command{
@@ -51,10 +129,60 @@ func main() {
 		cmd.PublishCommand,
 		cmd.SmokeTestCommand,
 	}
+	app.Before = func(c *cli.Context) error {
+		backend, err := broadcast.NewBackend(broadcastAPI, broadcast.Config{
+			Network:         btcNetwork,
+			ElectrumServers: electrumServers.Value(),
+			ElectrumUseTLS:  electrumUseTLS,
+			BitcoindRPCURL:  bitcoindRPCURL,
+			BitcoindRPCUser: bitcoindRPCUser,
+			BitcoindRPCPass: bitcoindRPCPass,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to construct broadcast-api backend: [%v]", err)
+		}
+
+		// Commands reach the constructed backend through c.App.Metadata,
+		// the conventional way urfave/cli shares App.Before-built
+		// dependencies with command Actions; broadcast.FromMetadata reads
+		// it back out under broadcast.MetadataKey.
+		c.App.Metadata[broadcast.MetadataKey] = backend
+
+		// The tss pre-params pool is only needed by commands that generate
+		// threshold keys, so it is only constructed when a store directory
+		// is configured; GenerateThresholdSignerUsingPool is handed the
+		// pool via preparams.FromMetadata(c.App.Metadata).
+		if tssPreParamsStore != "" {
+			encryptionKey, err := hex.DecodeString(tssPreParamsEncryptionKey)
+			if err != nil {
+				return fmt.Errorf("failed to decode tss-preparams-encryption-key: [%v]", err)
+			}
+
+			pool, err := preparams.NewPool(preparams.Config{
+				TargetSize:            tssPreParamsTargetSize,
+				GenerationConcurrency: tssPreParamsConcurrency,
+				StorePath:             tssPreParamsStore,
+			}, encryptionKey)
+			if err != nil {
+				return fmt.Errorf("failed to construct tss pre-params pool: [%v]", err)
+			}
+
+			c.App.Metadata[preparams.MetadataKey] = pool
+		}
+
+		return nil
+	}
+	app.After = func(c *cli.Context) error {
+		if pool, err := preparams.FromMetadata(c.App.Metadata); err == nil {
+			pool.Close()
+		}
+
+		return nil
+	}
 
 	err := app.Run(os.Args)
 
 	if err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
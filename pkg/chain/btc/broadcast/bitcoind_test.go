@@ -0,0 +1,38 @@
+package broadcast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBitcoindGetUTXOsRoundsSatoshiValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":[
+			{"txid":"a","vout":0,"amount":0.29},
+			{"txid":"b","vout":1,"amount":5.1}
+		]}`))
+	}))
+	defer server.Close()
+
+	backend, err := newBitcoindBackend(server.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utxos, err := backend.GetUTXOs(context.Background(), "addr")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []int64{29000000, 510000000}
+	for i, want := range expected {
+		if utxos[i].ValueSatoshi != want {
+			t.Errorf(
+				"utxo[%d]: expected %d satoshi, got %d",
+				i, want, utxos[i].ValueSatoshi,
+			)
+		}
+	}
+}
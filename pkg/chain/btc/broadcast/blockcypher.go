@@ -0,0 +1,139 @@
+package broadcast
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// blockCypherBaseURL is the root of BlockCypher's REST API.
+const blockCypherBaseURL = "https://api.blockcypher.com/v1/btc"
+
+// blockCypherBackend talks to the BlockCypher REST API.
+type blockCypherBackend struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// newBlockCypherBackend creates a Backend backed by BlockCypher's REST API
+// for the given network, e.g. "main" or "test3".
+func newBlockCypherBackend(network string) Backend {
+	if network == "" {
+		network = "main"
+	}
+
+	return &blockCypherBackend{
+		baseURL:    fmt.Sprintf("%s/%s", blockCypherBaseURL, network),
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (b *blockCypherBackend) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	payload, err := json.Marshal(struct {
+		Tx string `json:"tx"`
+	}{Tx: hex.EncodeToString(rawTx)})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction push request: [%v]", err)
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		b.baseURL+"/txs/push",
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction push request: [%v]", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to push transaction: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("blockcypher returned unexpected status: [%s]", response.Status)
+	}
+
+	var result struct {
+		Tx struct {
+			Hash string `json:"hash"`
+		} `json:"tx"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode transaction push response: [%v]", err)
+	}
+
+	return result.Tx.Hash, nil
+}
+
+func (b *blockCypherBackend) EstimateFee(ctx context.Context, vbytes int) (int64, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build chain info request: [%v]", err)
+	}
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch chain info: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	var result struct {
+		MediumFeePerKb int64 `json:"medium_fee_per_kb"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode chain info response: [%v]", err)
+	}
+
+	return result.MediumFeePerKb / 1000, nil
+}
+
+func (b *blockCypherBackend) GetUTXOs(ctx context.Context, addr string) ([]UTXO, error) {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/addrs/%s?unspentOnly=true", b.baseURL, addr),
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build address request: [%v]", err)
+	}
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch address: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	var result struct {
+		TxRefs []struct {
+			TxHash    string `json:"tx_hash"`
+			TxOutputN uint32 `json:"tx_output_n"`
+			Value     int64  `json:"value"`
+			Spent     bool   `json:"spent"`
+		} `json:"txrefs"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode address response: [%v]", err)
+	}
+
+	utxos := make([]UTXO, 0, len(result.TxRefs))
+	for _, ref := range result.TxRefs {
+		if ref.Spent {
+			continue
+		}
+		utxos = append(utxos, UTXO{
+			TxID:         ref.TxHash,
+			OutputIndex:  ref.TxOutputN,
+			ValueSatoshi: ref.Value,
+		})
+	}
+
+	return utxos, nil
+}
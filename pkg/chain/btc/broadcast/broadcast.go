@@ -0,0 +1,29 @@
+// Package broadcast defines a pluggable interface for the external services
+// used to publish signed Bitcoin transactions, estimate fees, and look up
+// spendable outputs. Selecting an implementation is controlled by the
+// `broadcast-api` CLI flag.
+package broadcast
+
+import "context"
+
+// UTXO is a spendable output as reported by a Backend.
+type UTXO struct {
+	TxID         string
+	OutputIndex  uint32
+	ValueSatoshi int64
+}
+
+// Backend is implemented by every external service keep-tecdsa can use to
+// interact with the Bitcoin network on behalf of a keep.
+type Backend interface {
+	// Broadcast submits rawTx, a serialized signed transaction, to the
+	// Bitcoin network and returns its transaction ID.
+	Broadcast(ctx context.Context, rawTx []byte) (txid string, err error)
+
+	// EstimateFee returns a fee rate, in satoshis per virtual byte,
+	// appropriate for a transaction of the given virtual size.
+	EstimateFee(ctx context.Context, vbytes int) (satPerVByte int64, err error)
+
+	// GetUTXOs returns the spendable outputs currently held by addr.
+	GetUTXOs(ctx context.Context, addr string) ([]UTXO, error)
+}
@@ -0,0 +1,187 @@
+package broadcast
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// electrumDialTimeout bounds how long connecting to a single Electrum server
+// is allowed to take before failing over to the next one.
+const electrumDialTimeout = 5 * time.Second
+
+// electrumBackend talks to an Electrum server over its JSON-RPC protocol,
+// failing over across a list of candidate servers.
+type electrumBackend struct {
+	servers []string
+	useTLS  bool
+}
+
+// newElectrumBackend creates a Backend backed by the first reachable server
+// in servers, retried per-call so that a server dropping mid-session does
+// not require restarting the process.
+func newElectrumBackend(servers []string, useTLS bool) (Backend, error) {
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("electrum backend requires at least one server")
+	}
+
+	return &electrumBackend{servers: servers, useTLS: useTLS}, nil
+}
+
+// electrumRequest is a JSON-RPC 2.0 request as expected by Electrum servers.
+type electrumRequest struct {
+	ID     int           `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// electrumResponse is a JSON-RPC 2.0 response as returned by Electrum
+// servers.
+type electrumResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call issues method/params against electrumBackend's servers, in order,
+// moving on to the next server on any error, and returns the first result
+// obtained.
+func (e *electrumBackend) call(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	var lastErr error
+
+	for _, server := range e.servers {
+		result, err := e.callServer(ctx, server, method, params)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("all electrum servers failed, last error: [%v]", lastErr)
+}
+
+func (e *electrumBackend) callServer(
+	ctx context.Context,
+	server string,
+	method string,
+	params []interface{},
+) (json.RawMessage, error) {
+	dialer := &net.Dialer{Timeout: electrumDialTimeout}
+
+	var conn net.Conn
+	var err error
+	if e.useTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", server, &tls.Config{})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", server)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to electrum server [%s]: [%v]", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	request, err := json.Marshal(electrumRequest{ID: 0, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode electrum request: [%v]", err)
+	}
+
+	if _, err := conn.Write(append(request, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write electrum request to [%s]: [%v]", server, err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read electrum response from [%s]: [%v]", server, err)
+	}
+
+	var response electrumResponse
+	if err := json.Unmarshal(line, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode electrum response from [%s]: [%v]", server, err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("electrum server [%s] returned error: [%s]", server, response.Error.Message)
+	}
+
+	return response.Result, nil
+}
+
+func (e *electrumBackend) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	result, err := e.call(ctx, "blockchain.transaction.broadcast", hex.EncodeToString(rawTx))
+	if err != nil {
+		return "", err
+	}
+
+	var txid string
+	if err := json.Unmarshal(result, &txid); err != nil {
+		return "", fmt.Errorf("failed to decode broadcast result: [%v]", err)
+	}
+
+	return txid, nil
+}
+
+func (e *electrumBackend) EstimateFee(ctx context.Context, vbytes int) (int64, error) {
+	// blockchain.estimatefee takes a target number of confirmation blocks
+	// and returns a fee rate in BTC/kB; 6 blocks is a reasonable default
+	// for a non-urgent keep transaction.
+	result, err := e.call(ctx, "blockchain.estimatefee", 6)
+	if err != nil {
+		return 0, err
+	}
+
+	var btcPerKb float64
+	if err := json.Unmarshal(result, &btcPerKb); err != nil {
+		return 0, fmt.Errorf("failed to decode fee estimate: [%v]", err)
+	}
+	if btcPerKb <= 0 {
+		return 0, fmt.Errorf("electrum server returned no fee estimate")
+	}
+
+	satPerVByte := int64(btcPerKb * 1e8 / 1000)
+	if satPerVByte < 1 {
+		satPerVByte = 1
+	}
+
+	return satPerVByte, nil
+}
+
+func (e *electrumBackend) GetUTXOs(ctx context.Context, addr string) ([]UTXO, error) {
+	scriptHash, err := electrumScriptHash(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive script hash for [%s]: [%v]", addr, err)
+	}
+
+	result, err := e.call(ctx, "blockchain.scripthash.listunspent", scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		TxHash string `json:"tx_hash"`
+		TxPos  uint32 `json:"tx_pos"`
+		Value  int64  `json:"value"`
+	}
+	if err := json.Unmarshal(result, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode unspent outputs: [%v]", err)
+	}
+
+	utxos := make([]UTXO, len(entries))
+	for i, entry := range entries {
+		utxos[i] = UTXO{
+			TxID:         entry.TxHash,
+			OutputIndex:  entry.TxPos,
+			ValueSatoshi: entry.Value,
+		}
+	}
+
+	return utxos, nil
+}
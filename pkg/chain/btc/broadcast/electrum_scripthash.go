@@ -0,0 +1,37 @@
+package broadcast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
+
+// electrumScriptHash derives the Electrum protocol's "script hash" for addr:
+// the reversed, hex-encoded SHA-256 digest of the address's output script,
+// as used to key `blockchain.scripthash.*` RPCs.
+func electrumScriptHash(addr string) (string, error) {
+	decoded, err := btcutil.DecodeAddress(addr, &chaincfg.MainNetParams)
+	if err != nil {
+		decoded, err = btcutil.DecodeAddress(addr, &chaincfg.TestNet3Params)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode address: [%v]", err)
+		}
+	}
+
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to build output script: [%v]", err)
+	}
+
+	digest := sha256.Sum256(script)
+	reversed := make([]byte, len(digest))
+	for i, b := range digest {
+		reversed[len(digest)-1-i] = b
+	}
+
+	return hex.EncodeToString(reversed), nil
+}
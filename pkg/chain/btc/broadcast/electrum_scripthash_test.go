@@ -0,0 +1,23 @@
+package broadcast
+
+import "testing"
+
+func TestElectrumScriptHash(t *testing.T) {
+	// Test data: the witness pubkey hash address derived from the [BIP-173]
+	// example public key, and its corresponding Electrum script hash.
+	address := "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"
+	expectedScriptHash := "9623df75239b5daa7f5f03042d325b51498c4bb7059c7748b17049bf96f73888"
+
+	scriptHash, err := electrumScriptHash(address)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if scriptHash != expectedScriptHash {
+		t.Errorf(
+			"unexpected script hash\nexpected: %v\nactual:   %v\n",
+			expectedScriptHash,
+			scriptHash,
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package broadcast
+
+import "fmt"
+
+// Config holds the settings needed to construct any of the supported
+// Backend implementations. Only the fields relevant to the selected
+// implementation need to be populated.
+type Config struct {
+	// Network is the Bitcoin network to operate on, e.g. "main" or
+	// "test3". Used by the BlockCypher backend.
+	Network string
+
+	// ElectrumServers is an ordered list of "host:port" Electrum servers to
+	// try, in order, until one succeeds. Used by the Electrum backend.
+	ElectrumServers []string
+	// ElectrumUseTLS connects to ElectrumServers over TLS when true.
+	ElectrumUseTLS bool
+
+	// BitcoindRPCURL is the JSON-RPC endpoint of a bitcoind-compatible
+	// node, e.g. "http://127.0.0.1:8332". Used by the bitcoind backend.
+	BitcoindRPCURL string
+	// BitcoindRPCUser and BitcoindRPCPass authenticate against
+	// BitcoindRPCURL.
+	BitcoindRPCUser string
+	BitcoindRPCPass string
+}
+
+// Names of the Backend implementations selectable via the `broadcast-api`
+// flag.
+const (
+	BlockCypher = "blockcypher"
+	Electrum    = "electrum"
+	Bitcoind    = "bitcoind"
+)
+
+// MetadataKey is the cli.App.Metadata key main.go's app.Before stores the
+// configured Backend under. Commands that need to broadcast a transaction,
+// estimate a fee, or look up UTXOs read it back via FromMetadata instead of
+// constructing their own Backend.
+const MetadataKey = "broadcastBackend"
+
+// FromMetadata returns the Backend stashed in metadata under MetadataKey, or
+// an error if app.Before never ran or stored one.
+func FromMetadata(metadata map[string]interface{}) (Backend, error) {
+	backend, ok := metadata[MetadataKey].(Backend)
+	if !ok {
+		return nil, fmt.Errorf("no broadcast-api backend configured")
+	}
+
+	return backend, nil
+}
+
+// NewBackend constructs the Backend named by name, configured from config.
+func NewBackend(name string, config Config) (Backend, error) {
+	switch name {
+	case BlockCypher:
+		return newBlockCypherBackend(config.Network), nil
+	case Electrum:
+		return newElectrumBackend(config.ElectrumServers, config.ElectrumUseTLS)
+	case Bitcoind:
+		return newBitcoindBackend(config.BitcoindRPCURL, config.BitcoindRPCUser, config.BitcoindRPCPass)
+	default:
+		return nil, fmt.Errorf(
+			"unknown broadcast-api backend [%s], expected one of: [%s, %s, %s]",
+			name,
+			BlockCypher,
+			Electrum,
+			Bitcoind,
+		)
+	}
+}
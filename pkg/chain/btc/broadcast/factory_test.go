@@ -0,0 +1,23 @@
+package broadcast
+
+import "testing"
+
+func TestNewBackendUnknownName(t *testing.T) {
+	if _, err := NewBackend("unknown", Config{}); err == nil {
+		t.Error("expected an error for an unknown backend name")
+	}
+}
+
+func TestNewBackendRequiresBackendSpecificConfig(t *testing.T) {
+	if _, err := NewBackend(Electrum, Config{}); err == nil {
+		t.Error("expected electrum backend to require at least one server")
+	}
+
+	if _, err := NewBackend(Bitcoind, Config{}); err == nil {
+		t.Error("expected bitcoind backend to require a JSON-RPC URL")
+	}
+
+	if _, err := NewBackend(BlockCypher, Config{}); err != nil {
+		t.Errorf("expected blockcypher backend to default its network, got: %v", err)
+	}
+}
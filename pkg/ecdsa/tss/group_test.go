@@ -0,0 +1,15 @@
+package tss
+
+import "testing"
+
+func TestValidateCurve(t *testing.T) {
+	if err := validateCurve(Secp256k1); err != nil {
+		t.Errorf("expected Secp256k1 to be a valid curve, got: %v", err)
+	}
+
+	for _, curve := range []Curve{P256, Ed25519} {
+		if err := validateCurve(curve); err == nil {
+			t.Errorf("expected curve [%v] to be rejected until it is wired up", curve)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package inactivity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-tecdsa/pkg/net"
+)
+
+// claimMessageType is the net.Message type used to broadcast and echo Claim
+// values during consensus.
+const claimMessageType = "ecdsa/tss_inactivity_claim"
+
+// claimMessage is the wire wrapper of Claim implementing net.TaggedMarshaler.
+type claimMessage struct {
+	Claim
+}
+
+// Type returns the string type of claimMessage so it conforms to the
+// `net.Message` interface.
+func (m *claimMessage) Type() string {
+	return claimMessageType
+}
+
+// Sign produces a detached signature over a claim's digest.
+type Sign func(digest []byte) ([]byte, error)
+
+// Verify checks that a claim's signature was produced by the claimed signer
+// over the claimed digest.
+type Verify func(signer MemberID, digest []byte, signature []byte) error
+
+// ReportAndConfirm broadcasts a Claim naming missing as inactive for round
+// over channel, then listens for matching claims echoed by peers. Once
+// dishonestThreshold+1 distinct, verifiably signed claims from members of
+// groupMemberIDs agree on the same accusation, it is considered confirmed
+// and the inactive set is returned. If ctx is cancelled first, no inactivity
+// is confirmed and ctx's error is returned instead.
+func ReportAndConfirm(
+	ctx context.Context,
+	channel net.BroadcastChannel,
+	groupID string,
+	sessionID string,
+	round string,
+	self MemberID,
+	groupMemberIDs []MemberID,
+	missing []MemberID,
+	dishonestThreshold int,
+	sign Sign,
+	verify Verify,
+) ([]MemberID, error) {
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	isGroupMember := make(map[string]bool, len(groupMemberIDs))
+	for _, memberID := range groupMemberIDs {
+		isGroupMember[memberID.String()] = true
+	}
+
+	claim := &Claim{
+		GroupID:   groupID,
+		SessionID: sessionID,
+		Round:     round,
+		Inactive:  missing,
+		Signer:    self,
+	}
+
+	signature, err := sign(claim.signingDigest())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign inactivity claim: [%v]", err)
+	}
+	claim.Signature = signature
+
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &claimMessage{}
+	})
+
+	if err := channel.Send(ctx, &claimMessage{Claim: *claim}); err != nil {
+		return nil, fmt.Errorf("failed to broadcast inactivity claim: [%v]", err)
+	}
+
+	// done is closed when ReportAndConfirm returns, for any reason. The
+	// channel.Recv subscription outlives this call with no unsubscribe
+	// mechanism available, so without done the handler below would block
+	// forever on a claim echoed after we've already returned (a slow peer,
+	// a retransmit, or a dishonest replay), leaking its goroutine.
+	done := make(chan struct{})
+	defer close(done)
+
+	messages := make(chan net.Message)
+	if err := channel.Recv(ctx, func(message net.Message) {
+		select {
+		case messages <- message:
+		case <-done:
+		case <-ctx.Done():
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("failed to subscribe for inactivity claims: [%v]", err)
+	}
+
+	confirmations := map[string]bool{self.String(): true}
+	if len(confirmations) >= dishonestThreshold+1 {
+		return missing, nil
+	}
+
+	for {
+		select {
+		case message := <-messages:
+			echoed, ok := message.(*claimMessage)
+			if !ok || !echoed.matches(claim) {
+				continue
+			}
+
+			if !isGroupMember[echoed.Signer.String()] {
+				continue
+			}
+
+			if err := verify(echoed.Signer, echoed.signingDigest(), echoed.Signature); err != nil {
+				continue
+			}
+
+			confirmations[echoed.Signer.String()] = true
+			if len(confirmations) >= dishonestThreshold+1 {
+				return missing, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package inactivity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-tecdsa/pkg/net"
+)
+
+// fakeChannel is a minimal net.BroadcastChannel that hands the handler
+// registered via Recv straight back to the test, so it can be driven
+// synchronously without a real network.
+type fakeChannel struct {
+	handler func(net.Message)
+	recvSet chan struct{}
+}
+
+func newFakeChannel() *fakeChannel {
+	return &fakeChannel{recvSet: make(chan struct{})}
+}
+
+func (c *fakeChannel) RegisterUnmarshaler(func() net.TaggedUnmarshaler) {}
+
+func (c *fakeChannel) Send(ctx context.Context, message net.Message) error {
+	return nil
+}
+
+func (c *fakeChannel) Recv(ctx context.Context, handler func(net.Message)) error {
+	c.handler = handler
+	close(c.recvSet)
+	return nil
+}
+
+type reportResult struct {
+	confirmed []MemberID
+	err       error
+}
+
+var testGroupMemberIDs = []MemberID{MemberID("alice"), MemberID("bob"), MemberID("carol")}
+
+func runReportAndConfirm(ctx context.Context, channel *fakeChannel, missing []MemberID, dishonestThreshold int) <-chan reportResult {
+	results := make(chan reportResult, 1)
+	go func() {
+		confirmed, err := ReportAndConfirm(
+			ctx,
+			channel,
+			"group",
+			"session",
+			"keygen",
+			MemberID("alice"),
+			testGroupMemberIDs,
+			missing,
+			dishonestThreshold,
+			func(digest []byte) ([]byte, error) { return []byte("sig"), nil },
+			func(signer MemberID, digest []byte, signature []byte) error { return nil },
+		)
+		results <- reportResult{confirmed, err}
+	}()
+	return results
+}
+
+func TestReportAndConfirmReachesQuorum(t *testing.T) {
+	channel := newFakeChannel()
+	missing := []MemberID{MemberID("bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := runReportAndConfirm(ctx, channel, missing, 1)
+
+	<-channel.recvSet
+	channel.handler(&claimMessage{Claim{
+		GroupID:   "group",
+		SessionID: "session",
+		Round:     "keygen",
+		Inactive:  missing,
+		Signer:    MemberID("carol"),
+		Signature: []byte("sig"),
+	}})
+
+	result := <-results
+	if result.err != nil {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+	if len(result.confirmed) != 1 || result.confirmed[0].String() != MemberID("bob").String() {
+		t.Fatalf("unexpected confirmed set: %v", result.confirmed)
+	}
+}
+
+func TestReportAndConfirmIgnoresMismatchedClaims(t *testing.T) {
+	channel := newFakeChannel()
+	missing := []MemberID{MemberID("bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results := runReportAndConfirm(ctx, channel, missing, 1)
+
+	<-channel.recvSet
+	channel.handler(&claimMessage{Claim{
+		GroupID:   "group",
+		SessionID: "session",
+		Round:     "signing", // different round, does not match our claim
+		Inactive:  missing,
+		Signer:    MemberID("carol"),
+		Signature: []byte("sig"),
+	}})
+
+	result := <-results
+	if result.err == nil {
+		t.Fatalf("expected ctx deadline error, got confirmed=%v", result.confirmed)
+	}
+}
+
+func TestReportAndConfirmIgnoresUnverifiedClaims(t *testing.T) {
+	channel := newFakeChannel()
+	missing := []MemberID{MemberID("bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results := make(chan reportResult, 1)
+	go func() {
+		confirmed, err := ReportAndConfirm(
+			ctx,
+			channel,
+			"group",
+			"session",
+			"keygen",
+			MemberID("alice"),
+			testGroupMemberIDs,
+			missing,
+			1,
+			func(digest []byte) ([]byte, error) { return []byte("sig"), nil },
+			func(signer MemberID, digest []byte, signature []byte) error {
+				return errors.New("forged signature")
+			},
+		)
+		results <- reportResult{confirmed, err}
+	}()
+
+	<-channel.recvSet
+	channel.handler(&claimMessage{Claim{
+		GroupID:   "group",
+		SessionID: "session",
+		Round:     "keygen",
+		Inactive:  missing,
+		Signer:    MemberID("carol"),
+		Signature: []byte("forged"),
+	}})
+
+	result := <-results
+	if result.err == nil {
+		t.Fatalf("expected ctx deadline error since the only echoed claim fails verification, got confirmed=%v", result.confirmed)
+	}
+}
+
+func TestReportAndConfirmHandlerDoesNotLeakAfterReturn(t *testing.T) {
+	channel := newFakeChannel()
+	missing := []MemberID{MemberID("bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := runReportAndConfirm(ctx, channel, missing, 1)
+
+	<-channel.recvSet
+	channel.handler(&claimMessage{Claim{
+		GroupID:   "group",
+		SessionID: "session",
+		Round:     "keygen",
+		Inactive:  missing,
+		Signer:    MemberID("carol"),
+		Signature: []byte("sig"),
+	}})
+
+	if result := <-results; result.err != nil {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+
+	// ReportAndConfirm has returned, but the channel subscription it
+	// registered is still live with nothing to unsubscribe it. A claim
+	// echoed now (a retransmit, a slow peer, a dishonest replay) must not
+	// block the handler goroutine forever.
+	handlerReturned := make(chan struct{})
+	go func() {
+		channel.handler(&claimMessage{Claim{
+			GroupID:   "group",
+			SessionID: "session",
+			Round:     "keygen",
+			Inactive:  missing,
+			Signer:    MemberID("carol"),
+			Signature: []byte("sig"),
+		}})
+		close(handlerReturned)
+	}()
+
+	select {
+	case <-handlerReturned:
+	case <-time.After(time.Second):
+		t.Fatal("handler leaked: blocked sending to messages after ReportAndConfirm returned")
+	}
+}
+
+func TestReportAndConfirmIgnoresNonMemberClaims(t *testing.T) {
+	channel := newFakeChannel()
+	missing := []MemberID{MemberID("bob")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results := runReportAndConfirm(ctx, channel, missing, 1)
+
+	<-channel.recvSet
+	// An outsider claim verifies fine against its own claimed signer, but
+	// "mallory" is not in the group and must not count towards quorum.
+	channel.handler(&claimMessage{Claim{
+		GroupID:   "group",
+		SessionID: "session",
+		Round:     "keygen",
+		Inactive:  missing,
+		Signer:    MemberID("mallory"),
+		Signature: []byte("sig"),
+	}})
+
+	result := <-results
+	if result.err == nil {
+		t.Fatalf("expected ctx deadline error since mallory is not a group member, got confirmed=%v", result.confirmed)
+	}
+}
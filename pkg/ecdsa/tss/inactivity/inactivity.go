@@ -0,0 +1,92 @@
+// Package inactivity implements a blame subsystem for the TSS protocol
+// executed by pkg/ecdsa/tss. It lets honest group members detect peers who
+// fail to send their expected keygen/signing message before a round's
+// deadline elapses, and run a lightweight one-round consensus so that a
+// missed deadline is only reported as inactivity once enough peers agree on
+// it. This mirrors the inactivity-proof pattern used by keep-core's tbtc
+// protocol.
+package inactivity
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MemberID is the on-wire identifier of a group member. It mirrors
+// tss.MemberID; it is redefined here, rather than imported, so that the tss
+// package can depend on inactivity without creating an import cycle.
+type MemberID []byte
+
+// String returns a hex representation of the member ID.
+func (id MemberID) String() string {
+	return fmt.Sprintf("%x", []byte(id))
+}
+
+// Claim is a member's signed accusation that the members listed in Inactive
+// failed to send their expected message for Round before the round's
+// deadline elapsed.
+type Claim struct {
+	GroupID   string
+	SessionID string
+	Round     string
+	Inactive  []MemberID
+	Signer    MemberID
+	Signature []byte
+}
+
+// contentDigest returns the bytes identifying what a Claim accuses,
+// excluding Signer and Signature, so that every honest observer of the same
+// missed deadline agrees on the same contentDigest regardless of who is
+// reporting it or in what order they happened to enumerate Inactive.
+func (c *Claim) contentDigest() []byte {
+	inactive := make([]string, len(c.Inactive))
+	for i, memberID := range c.Inactive {
+		// Hex-encode each member ID before delimiting: MemberIDs are
+		// arbitrary bytes (e.g. serialized public keys) and could otherwise
+		// contain '|' themselves, letting two different Inactive sets hash
+		// to the same digest.
+		inactive[i] = memberID.String()
+	}
+	sort.Strings(inactive)
+
+	digest := c.GroupID + "|" + c.SessionID + "|" + c.Round + "|"
+	for _, memberID := range inactive {
+		digest += memberID + "|"
+	}
+	return []byte(digest)
+}
+
+// signingDigest returns the bytes a Claim's Signature is computed and
+// verified over: contentDigest bound to Signer, so that a signature cannot
+// be replayed as if a different member had produced it.
+func (c *Claim) signingDigest() []byte {
+	return []byte(string(c.contentDigest()) + c.Signer.String() + "|")
+}
+
+// matches reports whether two claims accuse the same set of members of
+// missing the same round of the same session, regardless of who is
+// reporting it.
+func (c *Claim) matches(other *Claim) bool {
+	return string(c.contentDigest()) == string(other.contentDigest())
+}
+
+// Error is returned by GenerateThresholdSigner and CalculateSignature when a
+// round's deadline elapses and the consensus run by ReportAndConfirm
+// confirms one or more members as inactive. Callers such as the Ethereum
+// chain layer can inspect Inactive to slash or exclude those operators
+// before retrying.
+type Error struct {
+	GroupID   string
+	SessionID string
+	Round     string
+	Inactive  []MemberID
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(
+		"round [%s] timed out waiting for %v in group [%s]",
+		e.Round,
+		e.Inactive,
+		e.GroupID,
+	)
+}
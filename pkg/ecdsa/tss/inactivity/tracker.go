@@ -0,0 +1,46 @@
+package inactivity
+
+import "sync"
+
+// Tracker watches a single protocol round for the senders it expects to
+// hear from and reports which of them are still missing once the round's
+// deadline elapses.
+type Tracker struct {
+	mutex    sync.Mutex
+	expected map[string]MemberID
+}
+
+// NewTracker creates a Tracker expecting a message from every member of
+// groupMemberIDs other than self.
+func NewTracker(groupMemberIDs []MemberID, self MemberID) *Tracker {
+	expected := make(map[string]MemberID, len(groupMemberIDs))
+	for _, memberID := range groupMemberIDs {
+		if memberID.String() == self.String() {
+			continue
+		}
+		expected[memberID.String()] = memberID
+	}
+
+	return &Tracker{expected: expected}
+}
+
+// MarkSeen records that memberID's message for this round has arrived, so
+// it will no longer be reported as missing.
+func (t *Tracker) MarkSeen(memberID MemberID) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.expected, memberID.String())
+}
+
+// Missing returns the members that have not yet been seen for this round.
+func (t *Tracker) Missing() []MemberID {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	missing := make([]MemberID, 0, len(t.expected))
+	for _, memberID := range t.expected {
+		missing = append(missing, memberID)
+	}
+	return missing
+}
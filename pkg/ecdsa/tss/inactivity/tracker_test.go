@@ -0,0 +1,38 @@
+package inactivity
+
+import "testing"
+
+func TestTrackerMissing(t *testing.T) {
+	self := MemberID("alice")
+	bob := MemberID("bob")
+	carol := MemberID("carol")
+
+	tracker := NewTracker([]MemberID{self, bob, carol}, self)
+
+	missing := tracker.Missing()
+	if len(missing) != 2 {
+		t.Fatalf("expected 2 members missing before any are seen, got: %v", missing)
+	}
+
+	tracker.MarkSeen(bob)
+
+	missing = tracker.Missing()
+	if len(missing) != 1 || missing[0].String() != carol.String() {
+		t.Fatalf("expected only [%s] missing after marking [%s] seen, got: %v", carol, bob, missing)
+	}
+
+	tracker.MarkSeen(carol)
+
+	if missing := tracker.Missing(); len(missing) != 0 {
+		t.Fatalf("expected no members missing once all are seen, got: %v", missing)
+	}
+}
+
+func TestNewTrackerExcludesSelf(t *testing.T) {
+	self := MemberID("alice")
+	tracker := NewTracker([]MemberID{self}, self)
+
+	if missing := tracker.Missing(); len(missing) != 0 {
+		t.Fatalf("expected self to never be reported as missing, got: %v", missing)
+	}
+}
@@ -0,0 +1,113 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/inactivity"
+	"github.com/keep-network/keep-tecdsa/pkg/net"
+)
+
+// reportInactivity is invoked when a protocol round times out. It asks the
+// group's members to confirm which of them tracker still has not heard from
+// and, once dishonestThreshold+1 of them agree, returns an *inactivity.Error
+// the caller can surface instead of the raw timeout.
+//
+// tracker must have been fed every message received for round, via
+// netBridge.trackSenders running over the course of the round, so that
+// members who did participate are excluded from the report.
+func reportInactivity(
+	group *groupInfo,
+	round string,
+	tracker *inactivity.Tracker,
+	operatorPrivateKey *btcec.PrivateKey,
+	networkProvider net.Provider,
+) (*inactivity.Error, error) {
+	missing := tracker.Missing()
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	channel, err := networkProvider.ChannelFor(channelName(group) + "-inactivity")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inactivity broadcast channel: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), inactivityConfirmationTimeout)
+	defer cancel()
+
+	confirmed, err := inactivity.ReportAndConfirm(
+		ctx,
+		channel,
+		group.groupID,
+		group.sessionID,
+		round,
+		inactivity.MemberID(group.memberID),
+		toInactivityMemberIDs(group.groupMemberIDs),
+		missing,
+		group.dishonestThreshold,
+		signInactivityClaim(operatorPrivateKey),
+		verifyInactivityClaim,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inactivity.Error{
+		GroupID:   group.groupID,
+		SessionID: group.sessionID,
+		Round:     round,
+		Inactive:  confirmed,
+	}, nil
+}
+
+func toInactivityMemberIDs(memberIDs []MemberID) []inactivity.MemberID {
+	converted := make([]inactivity.MemberID, len(memberIDs))
+	for i, memberID := range memberIDs {
+		converted[i] = inactivity.MemberID(memberID)
+	}
+	return converted
+}
+
+// signInactivityClaim returns a Sign that produces a detached ECDSA
+// signature over a claim's digest using operatorPrivateKey. By convention, an
+// operator's inactivity.MemberID is the serialized compressed form of the
+// public key matching their operatorPrivateKey, which is what
+// verifyInactivityClaim checks signatures against; there is no shared secret
+// for a dishonest operator to forge another operator's claim with.
+func signInactivityClaim(operatorPrivateKey *btcec.PrivateKey) inactivity.Sign {
+	return func(digest []byte) ([]byte, error) {
+		hash := sha256.Sum256(digest)
+		signature, err := operatorPrivateKey.Sign(hash[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign inactivity claim: [%v]", err)
+		}
+		return signature.Serialize(), nil
+	}
+}
+
+// verifyInactivityClaim checks that signature is signer's own ECDSA
+// signature over digest, treating signer as the serialized compressed public
+// key of the operator who produced it.
+func verifyInactivityClaim(signer inactivity.MemberID, digest []byte, signature []byte) error {
+	publicKey, err := btcec.ParsePubKey(signer, btcec.S256())
+	if err != nil {
+		return fmt.Errorf("failed to parse claimed signer's public key: [%v]", err)
+	}
+
+	parsedSignature, err := btcec.ParseSignature(signature, btcec.S256())
+	if err != nil {
+		return fmt.Errorf("failed to parse inactivity claim signature: [%v]", err)
+	}
+
+	hash := sha256.Sum256(digest)
+	if !parsedSignature.Verify(hash[:], publicKey) {
+		return errors.New("inactivity claim signature does not match its claimed signer")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package tss
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/inactivity"
+)
+
+func TestVerifyInactivityClaimRejectsWrongSigner(t *testing.T) {
+	operatorKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	impostorKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := []byte("claim-digest")
+
+	signature, err := signInactivityClaim(operatorKey)(digest)
+	if err != nil {
+		t.Fatalf("unexpected signing error: %v", err)
+	}
+
+	operatorID := inactivity.MemberID(operatorKey.PubKey().SerializeCompressed())
+	if err := verifyInactivityClaim(operatorID, digest, signature); err != nil {
+		t.Errorf("expected signature to verify against its own signer, got: %v", err)
+	}
+
+	impostorID := inactivity.MemberID(impostorKey.PubKey().SerializeCompressed())
+	if err := verifyInactivityClaim(impostorID, digest, signature); err == nil {
+		t.Error("expected a real operator signature to be rejected under a different claimed signer")
+	}
+}
@@ -10,31 +10,42 @@ import (
 	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/gen/pb"
 )
 
+// signerVersion is the current on-disk/on-chain format version for
+// ThresholdSigner. A blob with no version set (the zero value) predates the
+// introduction of Version and Curve; Unmarshal treats it as version 1,
+// Secp256k1-only, for backward compatibility.
+const signerVersion uint32 = 1
+
 // Marshal converts ThresholdSigner to byte array.
 func (s *ThresholdSigner) Marshal() ([]byte, error) {
-	// Threshold key
-	keygenData, err := s.thresholdKey.Marshal()
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert local party save data: [%v]", err)
-	}
-
-	// Group Info
-	groupMemberIDs := make([][]byte, len(s.groupMemberIDs))
-	for i, memberID := range s.groupMemberIDs {
-		groupMemberIDs[i] = memberID
+	pbSigner := &pb.ThresholdSigner{
+		Version: signerVersion,
+		Curve:   pb.ThresholdSigner_Curve(s.curve),
+		GroupInfo: &pb.ThresholdSigner_GroupInfo{
+			GroupID:            s.groupID,
+			SessionID:          s.sessionID,
+			MemberID:           s.memberID,
+			GroupMemberIDs:     marshalMemberIDs(s.groupMemberIDs),
+			DishonestThreshold: int32(s.dishonestThreshold),
+		},
 	}
 
-	group := &pb.ThresholdSigner_GroupInfo{
-		GroupID:            s.groupID,
-		MemberID:           s.memberID,
-		GroupMemberIDs:     groupMemberIDs,
-		DishonestThreshold: int32(s.dishonestThreshold),
+	switch s.curve {
+	case Ed25519:
+		eddsaKeyData, err := s.eddsaThresholdKey.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert eddsa local party save data: [%v]", err)
+		}
+		pbSigner.EddsaThresholdKey = eddsaKeyData
+	default:
+		keygenData, err := s.thresholdKey.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert local party save data: [%v]", err)
+		}
+		pbSigner.ThresholdKey = keygenData
 	}
 
-	return (&pb.ThresholdSigner{
-		GroupInfo:    group,
-		ThresholdKey: keygenData,
-	}).Marshal()
+	return pbSigner.Marshal()
 }
 
 // Unmarshal converts a byte array back to ThresholdSigner.
@@ -46,10 +57,24 @@ func (s *ThresholdSigner) Unmarshal(bytes []byte) error {
 		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
 	}
 
-	// Threshold key
-	s.thresholdKey = ThresholdKey{}
-	if err := s.thresholdKey.Unmarshal(pbSigner.GetThresholdKey()); err != nil {
-		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	// A blob with no version is a pre-Curve, Secp256k1-only ECDSA blob.
+	curve := Secp256k1
+	if pbSigner.GetVersion() > 0 {
+		curve = Curve(pbSigner.GetCurve())
+	}
+	s.curve = curve
+
+	switch curve {
+	case Ed25519:
+		s.eddsaThresholdKey = EdDSAThresholdKey{}
+		if err := s.eddsaThresholdKey.Unmarshal(pbSigner.GetEddsaThresholdKey()); err != nil {
+			return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+		}
+	default:
+		s.thresholdKey = ThresholdKey{}
+		if err := s.thresholdKey.Unmarshal(pbSigner.GetThresholdKey()); err != nil {
+			return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+		}
 	}
 
 	// Group Info
@@ -62,6 +87,7 @@ func (s *ThresholdSigner) Unmarshal(bytes []byte) error {
 
 	s.groupInfo = &groupInfo{
 		groupID:            pbGroupInfo.GetGroupID(),
+		sessionID:          pbGroupInfo.GetSessionID(),
 		memberID:           MemberID(pbGroupInfo.GetMemberID()),
 		groupMemberIDs:     groupMemberIDs,
 		dishonestThreshold: int(pbGroupInfo.GetDishonestThreshold()),
@@ -70,6 +96,16 @@ func (s *ThresholdSigner) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// marshalMemberIDs converts a slice of MemberID to the raw byte slices used
+// by the wire format.
+func marshalMemberIDs(memberIDs []MemberID) [][]byte {
+	raw := make([][]byte, len(memberIDs))
+	for i, memberID := range memberIDs {
+		raw[i] = memberID
+	}
+	return raw
+}
+
 // Marshal converts thresholdKey to byte array.
 func (tk *ThresholdKey) Marshal() ([]byte, error) {
 	localPreParams := &pb.LocalPartySaveData_LocalPreParams{
@@ -191,10 +227,72 @@ func (tk *ThresholdKey) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts eddsaThresholdKey to byte array.
+func (tk *EdDSAThresholdKey) Marshal() ([]byte, error) {
+	ks := make([][]byte, len(tk.Ks))
+	for i, k := range tk.Ks {
+		ks[i] = k.Bytes()
+	}
+
+	bigXj := make([][]byte, len(tk.BigXj))
+	for i, bigX := range tk.BigXj {
+		encoded, err := bigX.GobEncode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode BigXj: [%v]", err)
+		}
+		bigXj[i] = encoded
+	}
+
+	eddsaPub, err := tk.EDDSAPub.GobEncode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode EDDSAPub: [%v]", err)
+	}
+
+	return (&pb.EdDSALocalPartySaveData{
+		Xi:       tk.Xi.Bytes(),
+		ShareID:  tk.ShareID.Bytes(),
+		Ks:       ks,
+		BigXj:    bigXj,
+		EddsaPub: eddsaPub,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array back to eddsaThresholdKey.
+func (tk *EdDSAThresholdKey) Unmarshal(bytes []byte) error {
+	pbData := pb.EdDSALocalPartySaveData{}
+	if err := pbData.Unmarshal(bytes); err != nil {
+		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	tk.Xi = new(big.Int).SetBytes(pbData.GetXi())
+	tk.ShareID = new(big.Int).SetBytes(pbData.GetShareID())
+
+	tk.Ks = make([]*big.Int, len(pbData.GetKs()))
+	for i, k := range pbData.GetKs() {
+		tk.Ks[i] = new(big.Int).SetBytes(k)
+	}
+
+	tk.BigXj = make([]*crypto.ECPoint, len(pbData.GetBigXj()))
+	for i, bigX := range pbData.GetBigXj() {
+		tk.BigXj[i] = &crypto.ECPoint{}
+		if err := tk.BigXj[i].GobDecode(bigX); err != nil {
+			return fmt.Errorf("failed to decode BigXj: [%v]", err)
+		}
+	}
+
+	tk.EDDSAPub = &crypto.ECPoint{}
+	if err := tk.EDDSAPub.GobDecode(pbData.GetEddsaPub()); err != nil {
+		return fmt.Errorf("failed to decode EDDSAPub: [%v]", err)
+	}
+
+	return nil
+}
+
 // Marshal converts this message to a byte array suitable for network communication.
 func (m *TSSProtocolMessage) Marshal() ([]byte, error) {
 	return (&pb.TSSProtocolMessage{
 		SenderID:    m.SenderID,
+		SessionID:   m.SessionID,
 		Payload:     m.Payload,
 		IsBroadcast: m.IsBroadcast,
 	}).Marshal()
@@ -208,6 +306,7 @@ func (m *TSSProtocolMessage) Unmarshal(bytes []byte) error {
 	}
 
 	m.SenderID = MemberID(pbMsg.SenderID)
+	m.SessionID = pbMsg.SessionID
 	m.Payload = pbMsg.Payload
 	m.IsBroadcast = pbMsg.IsBroadcast
 
@@ -217,7 +316,8 @@ func (m *TSSProtocolMessage) Unmarshal(bytes []byte) error {
 // Marshal converts this message to a byte array suitable for network communication.
 func (m *JoinMessage) Marshal() ([]byte, error) {
 	return (&pb.JoinMessage{
-		SenderID: m.SenderID,
+		SenderID:  m.SenderID,
+		SessionID: m.SessionID,
 	}).Marshal()
 }
 
@@ -229,6 +329,28 @@ func (m *JoinMessage) Unmarshal(bytes []byte) error {
 	}
 
 	m.SenderID = MemberID(pbMsg.SenderID)
+	m.SessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this message to a byte array suitable for network communication.
+func (m *AnnounceMessage) Marshal() ([]byte, error) {
+	return (&pb.AnnounceMessage{
+		SenderID:  m.SenderID,
+		SessionID: m.SessionID,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a message.
+func (m *AnnounceMessage) Unmarshal(bytes []byte) error {
+	pbMsg := &pb.AnnounceMessage{}
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	m.SenderID = MemberID(pbMsg.SenderID)
+	m.SessionID = pbMsg.SessionID
 
 	return nil
 }
@@ -0,0 +1,25 @@
+package tss
+
+import "testing"
+
+// TestMarshalPanicsOnUnpopulatedEdDSAKey documents the constraint
+// validateCurve enforces: a ThresholdSigner must never carry curve ==
+// Ed25519 without a populated eddsaThresholdKey, because Marshal's Ed25519
+// branch reads straight through to tk.Xi/tk.ShareID/etc. and panics on
+// their nil zero values otherwise. GenerateThresholdSigner and
+// CalculateSignature now refuse to build or use such a signer via
+// validateCurve; this test pins down why that guard exists.
+func TestMarshalPanicsOnUnpopulatedEdDSAKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Marshal to panic on an unpopulated eddsaThresholdKey")
+		}
+	}()
+
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{groupID: "group", memberID: MemberID("member")},
+		curve:     Ed25519,
+	}
+
+	signer.Marshal()
+}
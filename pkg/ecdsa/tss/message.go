@@ -5,6 +5,7 @@ package tss
 // implementation of the protocol.
 type TSSProtocolMessage struct {
 	SenderID    MemberID
+	SessionID   string
 	Payload     []byte
 	IsBroadcast bool
 }
@@ -18,7 +19,8 @@ func (m *TSSProtocolMessage) Type() string {
 // JoinMessage is a network message used to notify peer members about readiness
 // to start protocol execution.
 type JoinMessage struct {
-	SenderID MemberID
+	SenderID  MemberID
+	SessionID string
 }
 
 // Type returns a string type of the `JoinMessage`.
@@ -28,7 +30,8 @@ func (m *JoinMessage) Type() string {
 
 // AnnounceMessage is a network message used to announce peer's presence.
 type AnnounceMessage struct {
-	SenderID MemberID
+	SenderID  MemberID
+	SessionID string
 }
 
 // Type returns a string type of the `AnnounceMessage`.
@@ -0,0 +1,103 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/inactivity"
+	"github.com/keep-network/keep-tecdsa/pkg/net"
+)
+
+// netBridge adapts a group's TSS protocol messages to the underlying
+// net.Provider. Channels are named after the combination of groupID and
+// sessionID so that multiple key generation or signing sessions running
+// between the same subset of peers do not cross-talk on the wire.
+type netBridge struct {
+	networkProvider net.Provider
+	channel         net.BroadcastChannel
+}
+
+// channelName returns the routing name for a group's session, combining
+// groupID and sessionID so that concurrent sessions between the same peers
+// are multiplexed onto distinct channels.
+func channelName(group *groupInfo) string {
+	return fmt.Sprintf("%s/%s", group.groupID, group.sessionID)
+}
+
+// newNetworkBridge creates a netBridge scoped to group's (groupID,
+// sessionID) pair and registers the unmarshalers for the message types the
+// TSS protocol exchanges over it.
+func newNetworkBridge(group *groupInfo, networkProvider net.Provider) (*netBridge, error) {
+	channel, err := networkProvider.ChannelFor(channelName(group))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast channel: [%v]", err)
+	}
+
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TSSProtocolMessage{}
+	})
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &JoinMessage{}
+	})
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &AnnounceMessage{}
+	})
+
+	return &netBridge{networkProvider: networkProvider, channel: channel}, nil
+}
+
+// startInactivityTracking builds a Tracker expecting a round message from
+// every other member of group and starts trackSendersInBackground to feed it
+// for as long as the returned stop function has not been called. Callers
+// should defer the stop function so tracking ends when the round does.
+func startInactivityTracking(group *groupInfo, netBridge *netBridge) (*inactivity.Tracker, func()) {
+	tracker := inactivity.NewTracker(
+		toInactivityMemberIDs(group.groupMemberIDs),
+		inactivity.MemberID(group.memberID),
+	)
+
+	ctx, stop := context.WithCancel(context.Background())
+	netBridge.trackSendersInBackground(ctx, tracker)
+
+	return tracker, stop
+}
+
+// trackSendersInBackground starts trackSenders on a goroutine and logs if it
+// exits with anything other than ctx being cancelled by the caller, so that
+// a failed inactivity-tracking subscription is never silently swallowed: a
+// tracker that never observed any message would otherwise report the whole
+// group as inactive on the round's next timeout.
+func (b *netBridge) trackSendersInBackground(ctx context.Context, tracker *inactivity.Tracker) {
+	go func() {
+		if err := b.trackSenders(ctx, tracker); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Warningf("inactivity tracking subscription failed: [%v]", err)
+		}
+	}()
+}
+
+// trackSenders subscribes to the bridge's channel and marks the sender of
+// every received protocol message as seen on tracker, so that
+// tracker.Missing only ever reports members the bridge has genuinely not
+// heard a round message from. It returns once ctx is done or the underlying
+// subscription fails.
+func (b *netBridge) trackSenders(ctx context.Context, tracker *inactivity.Tracker) error {
+	return b.channel.Recv(ctx, func(message net.Message) {
+		if senderID, ok := protocolMessageSenderOf(message); ok {
+			tracker.MarkSeen(inactivity.MemberID(senderID))
+		}
+	})
+}
+
+// protocolMessageSenderOf extracts the sending member of message if message
+// is a TSSProtocolMessage: an actual protocol round message, as opposed to
+// the one-time JoinMessage/AnnounceMessage handshake exchanged before the
+// round starts, whose presence says nothing about whether a member kept
+// responding once the round was under way.
+func protocolMessageSenderOf(message net.Message) (MemberID, bool) {
+	protocolMessage, ok := message.(*TSSProtocolMessage)
+	if !ok {
+		return nil, false
+	}
+	return protocolMessage.SenderID, true
+}
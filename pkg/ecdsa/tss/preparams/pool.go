@@ -0,0 +1,179 @@
+// Package preparams maintains a background-replenished pool of tss-lib
+// key generation pre-parameters. Generating `keygen.LocalPreParams` involves
+// finding safe primes for the Paillier cryptosystem, which takes minutes and
+// would otherwise dominate the latency of every key generation protocol run.
+// By generating pre-parameters ahead of time and persisting the ones that
+// are ready, a restart does not discard that work and `GenerateThresholdSigner`
+// never has to fall back to generating them inline.
+package preparams
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-tss-preparams")
+
+// generatePreParamsTimeout bounds a single pre-parameters generation attempt.
+// Safe-prime generation is the dominant cost here and can occasionally run
+// long; a generous timeout avoids discarding otherwise-good work.
+const generatePreParamsTimeout = 5 * time.Minute
+
+// Config configures a Pool's target size and background generation
+// concurrency. main.go populates it from the `tss-preparams-*` command-line
+// flags; it is not read from `config.toml`.
+type Config struct {
+	// TargetSize is the number of ready pre-parameter sets the pool tries
+	// to keep on hand at all times.
+	TargetSize int
+	// GenerationConcurrency is the number of pre-parameter sets generated
+	// in parallel in the background.
+	GenerationConcurrency int
+	// StorePath is the directory ready pre-parameter sets are persisted to
+	// so that a restart does not discard work in progress.
+	StorePath string
+}
+
+// MetadataKey is the cli.App.Metadata key a long-lived Pool is expected to
+// be stored under by whichever command constructs it at startup, so that
+// GenerateThresholdSignerUsingPool can be handed the Pool without every
+// caller constructing its own Pool.
+const MetadataKey = "tssPreParamsPool"
+
+// FromMetadata returns the Pool stashed in metadata under MetadataKey, or an
+// error if nothing constructed one.
+func FromMetadata(metadata map[string]interface{}) (*Pool, error) {
+	pool, ok := metadata[MetadataKey].(*Pool)
+	if !ok {
+		return nil, fmt.Errorf("no tss pre-params pool configured")
+	}
+
+	return pool, nil
+}
+
+// Pool generates `keygen.LocalPreParams` in the background and hands them
+// out to callers of Get, persisting ready ones to disk so that a restart
+// does not lose generation work already done.
+type Pool struct {
+	config Config
+	store  *store
+
+	ready chan *keygen.LocalPreParams
+
+	generateOnce sync.Once
+	cancel       context.CancelFunc
+}
+
+// NewPool creates a Pool that persists ready pre-parameters under
+// config.StorePath, encrypted with encryptionKey, and starts
+// config.GenerationConcurrency background workers generating new ones until
+// config.TargetSize are on hand.
+func NewPool(config Config, encryptionKey []byte) (*Pool, error) {
+	if config.TargetSize <= 0 {
+		return nil, fmt.Errorf("pre-params pool target size must be positive, got [%d]", config.TargetSize)
+	}
+	if config.GenerationConcurrency <= 0 {
+		return nil, fmt.Errorf("pre-params pool generation concurrency must be positive, got [%d]", config.GenerationConcurrency)
+	}
+	if len(encryptionKey) == 0 {
+		return nil, fmt.Errorf("pre-params store requires a non-empty encryption key")
+	}
+
+	store, err := newStore(config.StorePath, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize pre-params store: [%v]", err)
+	}
+
+	persisted, err := store.loadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load persisted pre-params: [%v]", err)
+	}
+
+	// Size ready to hold at least every persisted set already on disk, not
+	// just config.TargetSize: TargetSize may have shrunk since these were
+	// generated, and the loop below would otherwise block forever with no
+	// reader running yet to drain it.
+	readySize := config.TargetSize
+	if len(persisted) > readySize {
+		readySize = len(persisted)
+	}
+
+	pool := &Pool{
+		config: config,
+		store:  store,
+		ready:  make(chan *keygen.LocalPreParams, readySize),
+	}
+
+	for _, preParams := range persisted {
+		pool.ready <- preParams
+	}
+	logger.Infof("loaded [%d] persisted tss pre-params", len(persisted))
+
+	pool.start()
+
+	return pool, nil
+}
+
+// start launches the background generation workers. It is idempotent.
+func (p *Pool) start() {
+	p.generateOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		p.cancel = cancel
+
+		for i := 0; i < p.config.GenerationConcurrency; i++ {
+			go p.generateLoop(ctx)
+		}
+	})
+}
+
+// generateLoop generates pre-parameters and hands them to p.ready, persisting
+// each one as soon as it is ready. p.ready is sized to TargetSize (or to the
+// number of persisted sets loaded at startup, if that is larger), so once
+// the pool is full the blocking send below parks the goroutine until Get
+// consumes one and frees a slot, rather than polling for one.
+func (p *Pool) generateLoop(ctx context.Context) {
+	for {
+		preParams, err := keygen.GeneratePreParams(generatePreParamsTimeout)
+		if err != nil {
+			logger.Errorf("failed to generate tss pre-params: [%v]", err)
+			continue
+		}
+
+		id, err := p.store.save(preParams)
+		if err != nil {
+			logger.Errorf("failed to persist tss pre-params: [%v]", err)
+		}
+
+		select {
+		case p.ready <- preParams:
+			logger.Infof("generated tss pre-params [%s]", id)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Get blocks until a pre-parameters set is available or ctx is done.
+func (p *Pool) Get(ctx context.Context) (*keygen.LocalPreParams, error) {
+	select {
+	case preParams := <-p.ready:
+		if err := p.store.delete(preParams); err != nil {
+			logger.Warningf("failed to remove consumed tss pre-params from store: [%v]", err)
+		}
+		return preParams, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the background generation workers.
+func (p *Pool) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
@@ -0,0 +1,160 @@
+package preparams
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+// persistedPreParams is the on-disk, gob-encodable representation of
+// `keygen.LocalPreParams`.
+type persistedPreParams struct {
+	PaillierPublicKey []byte
+	PaillierLambdaN   []byte
+	PaillierPhiN      []byte
+	NTildei           []byte
+	H1i               []byte
+	H2i               []byte
+}
+
+// store persists ready pre-parameter sets as files under dir, each encrypted
+// with encryptionKey so that an on-disk compromise does not itself leak
+// Paillier secrets.
+type store struct {
+	dir string
+	gcm cipher.AEAD
+}
+
+// newStore creates a store rooted at dir, deriving an AES-GCM key from
+// encryptionKey. dir is created if it does not already exist.
+func newStore(dir string, encryptionKey []byte) (*store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create pre-params store directory: [%v]", err)
+	}
+
+	derivedKey := sha256.Sum256(encryptionKey)
+	block, err := aes.NewCipher(derivedKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cipher: [%v]", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AEAD: [%v]", err)
+	}
+
+	return &store{dir: dir, gcm: gcm}, nil
+}
+
+// save encrypts and persists preParams, returning an identifier that can
+// later be used to locate it for deletion.
+func (s *store) save(preParams *keygen.LocalPreParams) (string, error) {
+	persisted := persistedPreParams{
+		PaillierPublicKey: preParams.PaillierSK.PublicKey.N.Bytes(),
+		PaillierLambdaN:   preParams.PaillierSK.LambdaN.Bytes(),
+		PaillierPhiN:      preParams.PaillierSK.PhiN.Bytes(),
+		NTildei:           preParams.NTildei.Bytes(),
+		H1i:               preParams.H1i.Bytes(),
+		H2i:               preParams.H2i.Bytes(),
+	}
+
+	var plaintext bytes.Buffer
+	if err := gob.NewEncoder(&plaintext).Encode(persisted); err != nil {
+		return "", fmt.Errorf("failed to encode pre-params: [%v]", err)
+	}
+
+	nonce := make([]byte, s.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: [%v]", err)
+	}
+
+	ciphertext := s.gcm.Seal(nonce, nonce, plaintext.Bytes(), nil)
+
+	id := hex.EncodeToString(persisted.NTildei)
+	if err := ioutil.WriteFile(s.path(id), ciphertext, 0600); err != nil {
+		return "", fmt.Errorf("failed to write pre-params file: [%v]", err)
+	}
+
+	return id, nil
+}
+
+// delete removes the persisted copy of preParams, if any.
+func (s *store) delete(preParams *keygen.LocalPreParams) error {
+	id := hex.EncodeToString(preParams.NTildei.Bytes())
+
+	err := os.Remove(s.path(id))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// loadAll decrypts and returns every pre-parameters set currently persisted
+// in the store.
+func (s *store) loadAll() ([]*keygen.LocalPreParams, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pre-params store: [%v]", err)
+	}
+
+	preParamsList := make([]*keygen.LocalPreParams, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ciphertext, err := ioutil.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pre-params file [%s]: [%v]", entry.Name(), err)
+		}
+
+		nonceSize := s.gcm.NonceSize()
+		if len(ciphertext) < nonceSize {
+			return nil, fmt.Errorf("pre-params file [%s] is corrupted", entry.Name())
+		}
+
+		nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+		plaintext, err := s.gcm.Open(nil, nonce, sealed, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt pre-params file [%s]: [%v]", entry.Name(), err)
+		}
+
+		var persisted persistedPreParams
+		if err := gob.NewDecoder(bytes.NewReader(plaintext)).Decode(&persisted); err != nil {
+			return nil, fmt.Errorf("failed to decode pre-params file [%s]: [%v]", entry.Name(), err)
+		}
+
+		preParamsList = append(preParamsList, &keygen.LocalPreParams{
+			PaillierSK: &paillier.PrivateKey{
+				PublicKey: paillier.PublicKey{
+					N: new(big.Int).SetBytes(persisted.PaillierPublicKey),
+				},
+				LambdaN: new(big.Int).SetBytes(persisted.PaillierLambdaN),
+				PhiN:    new(big.Int).SetBytes(persisted.PaillierPhiN),
+			},
+			NTildei: new(big.Int).SetBytes(persisted.NTildei),
+			H1i:     new(big.Int).SetBytes(persisted.H1i),
+			H2i:     new(big.Int).SetBytes(persisted.H2i),
+		})
+	}
+
+	return preParamsList, nil
+}
+
+// path returns the file path a pre-parameters set with the given id is
+// stored at.
+func (s *store) path(id string) string {
+	return filepath.Join(s.dir, id+".bin")
+}
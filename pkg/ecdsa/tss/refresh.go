@@ -0,0 +1,115 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-tecdsa/pkg/net"
+)
+
+// refreshTimeout bounds how long the key-refresh/resharing protocol runs
+// before giving up, mirroring keyGenerationTimeout.
+const refreshTimeout = 120 * time.Second
+
+// resharingRound names the round tracked for inactivity reporting by
+// Reshare.
+const resharingRound = "resharing"
+
+// Refresh runs a key-refresh protocol with s's current group, producing a
+// new ThresholdSigner whose ECDSAPub is unchanged but whose LocalSecrets.Xi,
+// Paillier keys, and NTilde values are freshly randomized. Refreshing a
+// signer on a schedule (e.g. quarterly) limits the exposure of any single
+// Paillier keypair over the lifetime of the keep.
+func (s *ThresholdSigner) Refresh(
+	ctx context.Context,
+	operatorPrivateKey *btcec.PrivateKey,
+	networkProvider net.Provider,
+) (*ThresholdSigner, error) {
+	return s.Reshare(ctx, s.groupInfo, operatorPrivateKey, networkProvider)
+}
+
+// Reshare runs the GG18/CGGMP-style key-refresh protocol from tss-lib's
+// `ecdsa/resharing` package against newGroup, producing a new
+// ThresholdSigner holding a freshly randomized share of the same ECDSA
+// public key. newGroup may name a different member set and/or dishonest
+// threshold than s's current group, supporting operator rotation; passing
+// s's own groupInfo runs a same-membership key refresh.
+//
+// Reshare reuses the netBridge, joinProtocol, and marshaling machinery built
+// for key generation and signing, and the new signer is versioned the same
+// way so it can be persisted and later migrated like any other signer.
+func (s *ThresholdSigner) Reshare(
+	ctx context.Context,
+	newGroup *groupInfo,
+	operatorPrivateKey *btcec.PrivateKey,
+	networkProvider net.Provider,
+) (*ThresholdSigner, error) {
+	if err := validateCurve(s.curve); err != nil {
+		return nil, fmt.Errorf("failed to reshare key: [%v]", err)
+	}
+
+	if len(newGroup.groupMemberIDs) < 2 {
+		return nil, fmt.Errorf(
+			"group should have at least 2 members but got: [%d]",
+			len(newGroup.groupMemberIDs),
+		)
+	}
+
+	if len(newGroup.groupMemberIDs) <= newGroup.dishonestThreshold {
+		return nil, fmt.Errorf(
+			"group size [%d], should be greater than dishonest threshold [%d]",
+			len(newGroup.groupMemberIDs),
+			newGroup.dishonestThreshold,
+		)
+	}
+
+	// Build a local copy of newGroup carrying this call's own sessionID
+	// instead of writing through the caller-supplied pointer: Refresh passes
+	// s.groupInfo itself, and mutating that in place would race with any
+	// other call reading or signing against the same signer.
+	group := *newGroup
+	if group.sessionID == "" {
+		sessionID, err := generateSessionID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session ID: [%v]", err)
+		}
+		group.sessionID = sessionID
+	}
+
+	netBridge, err := newNetworkBridge(&group, networkProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	tracker, stopTracking := startInactivityTracking(&group, netBridge)
+	defer stopTracking()
+
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+
+	reshareSigner, err := initializeResharing(ctx, s.groupInfo, &group, &s.thresholdKey, netBridge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize resharing: [%v]", err)
+	}
+
+	if err := joinProtocol(ctx, &group, networkProvider); err != nil {
+		return nil, fmt.Errorf("failed to join the protocol: [%v]", err)
+	}
+
+	refreshed, err := reshareSigner.reshareKey(ctx)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			if inactivityErr, reportErr := reportInactivity(&group, resharingRound, tracker, operatorPrivateKey, networkProvider); reportErr == nil && inactivityErr != nil {
+				return nil, inactivityErr
+			}
+		}
+		return nil, fmt.Errorf("failed to reshare key: [%v]", err)
+	}
+
+	refreshed.curve = s.curve
+
+	return refreshed, nil
+}
@@ -0,0 +1,40 @@
+package tss
+
+import "testing"
+
+func TestReshareRequiresEnoughGroupMembers(t *testing.T) {
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{groupID: "group", memberID: MemberID("member-1")},
+		curve:     Secp256k1,
+	}
+
+	_, err := signer.Reshare(
+		nil,
+		&groupInfo{groupMemberIDs: []MemberID{MemberID("member-1")}, dishonestThreshold: 0},
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected an error when the new group has fewer than 2 members")
+	}
+}
+
+func TestReshareRequiresGroupLargerThanDishonestThreshold(t *testing.T) {
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{groupID: "group", memberID: MemberID("member-1")},
+		curve:     Secp256k1,
+	}
+
+	_, err := signer.Reshare(
+		nil,
+		&groupInfo{
+			groupMemberIDs:     []MemberID{MemberID("member-1"), MemberID("member-2")},
+			dishonestThreshold: 2,
+		},
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected an error when the new group size does not exceed the dishonest threshold")
+	}
+}
@@ -8,18 +8,32 @@ package tss
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-tecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-tecdsa/pkg/ecdsa/tss/preparams"
 	"github.com/keep-network/keep-tecdsa/pkg/net"
 )
 
 const (
 	keyGenerationTimeout = 120 * time.Second
 	signingTimeout       = 120 * time.Second
+
+	// inactivityConfirmationTimeout bounds how long members wait to collect
+	// enough matching, signed inactivity claims from their peers before
+	// giving up on confirming who caused a round timeout.
+	inactivityConfirmationTimeout = 30 * time.Second
+
+	// keygenRound and signingRound name the rounds tracked for inactivity
+	// reporting by GenerateThresholdSigner and CalculateSignature.
+	keygenRound  = "keygen"
+	signingRound = "signing"
 )
 
 var logger = log.Logger("keep-tss")
@@ -35,8 +49,22 @@ var logger = log.Logger("keep-tss")
 // of `t + 1` players can jointly sign, but any smaller subset cannot.
 //
 // TSS protocol requires pre-parameters such as safe primes to be generated for
-// execution. The parameters should be generated prior to running this function.
-// If not provided they will be generated.
+// execution. tssPreParams must be generated ahead of time, e.g. with a
+// preparams.Pool (see GenerateThresholdSignerUsingPool) or
+// keygen.GeneratePreParams directly; it is a required parameter since
+// generating it inline would stall key generation for minutes.
+//
+// sessionID distinguishes this execution from other key generation or signing
+// sessions running concurrently between the same groupMemberIDs. If empty, a
+// random session ID is generated.
+//
+// curve selects the signature scheme the generated key will support. Only
+// Secp256k1 runs the full tss-lib protocol end-to-end today; other curves
+// are rejected with an error until they are wired up.
+//
+// operatorPrivateKey signs this member's own inactivity claims if key
+// generation times out; its serialized compressed public key must match this
+// member's memberID, since that is what peers verify claims against.
 //
 // As a result a signer will be returned or an error, if key generation failed.
 func GenerateThresholdSigner(
@@ -44,6 +72,9 @@ func GenerateThresholdSigner(
 	memberID MemberID,
 	groupMemberIDs []MemberID,
 	dishonestThreshold uint,
+	sessionID string,
+	curve Curve,
+	operatorPrivateKey *btcec.PrivateKey,
 	networkProvider net.Provider,
 	tssPreParams *keygen.LocalPreParams,
 ) (*ThresholdSigner, error) {
@@ -62,19 +93,31 @@ func GenerateThresholdSigner(
 		)
 	}
 
+	if err := validateCurve(curve); err != nil {
+		return nil, fmt.Errorf("failed to generate threshold signer: [%v]", err)
+	}
+
+	if sessionID == "" {
+		generatedSessionID, err := generateSessionID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session ID: [%v]", err)
+		}
+		sessionID = generatedSessionID
+	}
+
 	group := &groupInfo{
 		groupID:            groupID,
+		sessionID:          sessionID,
 		memberID:           memberID,
 		groupMemberIDs:     groupMemberIDs,
 		dishonestThreshold: int(dishonestThreshold),
 	}
 
 	if tssPreParams == nil {
-		// TODO: Should we return an error here? We expect the params to be provided
-		// from pool but if they are not provided to this function they will
-		// be generated by underlying tss-lib protocol implementation anyway.
-		logger.Warningf(
-			"tss pre-params were not provided, they will be generated on protocol execution",
+		return nil, fmt.Errorf(
+			"tss pre-params are required; obtain them from a preparams.Pool " +
+				"or keygen.GeneratePreParams ahead of time, generating them inline " +
+				"here would stall key generation for minutes",
 		)
 	}
 
@@ -83,6 +126,9 @@ func GenerateThresholdSigner(
 		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
 	}
 
+	tracker, stopTracking := startInactivityTracking(group, netBridge)
+	defer stopTracking()
+
 	ctx, cancel := context.WithTimeout(context.Background(), keyGenerationTimeout)
 	defer cancel()
 
@@ -105,41 +151,136 @@ func GenerateThresholdSigner(
 
 	signer, err := keyGenSigner.generateKey(ctx)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			if inactivityErr, reportErr := reportInactivity(group, keygenRound, tracker, operatorPrivateKey, networkProvider); reportErr == nil && inactivityErr != nil {
+				return nil, inactivityErr
+			}
+		}
 		return nil, fmt.Errorf("failed to generate key: [%v]", err)
 	}
 	logger.Infof("[party:%s]: completed key generation", keyGenSigner.keygenParty.PartyID())
 
+	signer.curve = curve
+
 	return signer, nil
 }
 
+// GenerateThresholdSignerUsingPool behaves like GenerateThresholdSigner, but
+// draws its tss pre-parameters from pool instead of requiring the caller to
+// generate or hold onto them directly. It blocks until pool has a ready set
+// on hand or ctx is done, whichever comes first.
+func GenerateThresholdSignerUsingPool(
+	ctx context.Context,
+	groupID string,
+	memberID MemberID,
+	groupMemberIDs []MemberID,
+	dishonestThreshold uint,
+	sessionID string,
+	curve Curve,
+	operatorPrivateKey *btcec.PrivateKey,
+	networkProvider net.Provider,
+	pool *preparams.Pool,
+) (*ThresholdSigner, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("tss pre-params pool is required")
+	}
+
+	tssPreParams, err := pool.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tss pre-params from pool: [%v]", err)
+	}
+
+	return GenerateThresholdSigner(
+		groupID,
+		memberID,
+		groupMemberIDs,
+		dishonestThreshold,
+		sessionID,
+		curve,
+		operatorPrivateKey,
+		networkProvider,
+		tssPreParams,
+	)
+}
+
 // CalculateSignature executes a threshold multi-party signature calculation
-// protocol for the given digest. As a result the calculated ECDSA signature will
-// be returned or an error, if the signature generation failed.
+// protocol for the given digest. Only Secp256k1 signers run the tss-lib
+// protocol; signers of any other curve are rejected with an error. As a result the calculated
+// signature will be returned or an error, if the signature generation
+// failed.
+//
+// sessionID distinguishes this execution from other signing sessions running
+// concurrently between the same group members. If empty, a random session ID
+// is generated.
+//
+// operatorPrivateKey signs this member's own inactivity claims if signing
+// times out; its serialized compressed public key must match s's memberID,
+// since that is what peers verify claims against.
 func (s *ThresholdSigner) CalculateSignature(
 	digest []byte,
+	sessionID string,
+	operatorPrivateKey *btcec.PrivateKey,
 	networkProvider net.Provider,
 ) (*ecdsa.Signature, error) {
-	netBridge, err := newNetworkBridge(s.groupInfo, networkProvider)
+	if err := validateCurve(s.curve); err != nil {
+		return nil, fmt.Errorf("failed to calculate signature: [%v]", err)
+	}
+
+	if sessionID == "" {
+		generatedSessionID, err := generateSessionID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session ID: [%v]", err)
+		}
+		sessionID = generatedSessionID
+	}
+
+	// Build a local copy of the group carrying this call's own sessionID
+	// instead of writing through s.groupInfo: s may be signing concurrently
+	// under other sessionIDs, and mutating the receiver's shared groupInfo
+	// would race with those calls.
+	group := *s.groupInfo
+	group.sessionID = sessionID
+
+	netBridge, err := newNetworkBridge(&group, networkProvider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
 	}
 
+	tracker, stopTracking := startInactivityTracking(&group, netBridge)
+	defer stopTracking()
+
 	ctx, cancel := context.WithTimeout(context.Background(), keyGenerationTimeout)
 	defer cancel()
 
-	signingSigner, err := s.initializeSigning(ctx, digest[:], netBridge)
+	signingSigner, err := s.initializeSigning(ctx, &group, digest[:], netBridge)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize signing: [%v]", err)
 	}
 
-	if err := joinProtocol(ctx, s.groupInfo, networkProvider); err != nil {
+	if err := joinProtocol(ctx, &group, networkProvider); err != nil {
 		return nil, fmt.Errorf("failed to join the protocol:: [%v]", err)
 	}
 
 	signature, err := signingSigner.sign(ctx)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			if inactivityErr, reportErr := reportInactivity(&group, signingRound, tracker, operatorPrivateKey, networkProvider); reportErr == nil && inactivityErr != nil {
+				return nil, inactivityErr
+			}
+		}
 		return nil, fmt.Errorf("failed to sign: [%v]", err)
 	}
 
 	return signature, err
 }
+
+// generateSessionID returns a random, hex-encoded session identifier used to
+// distinguish concurrent key generation or signing sessions running between
+// the same group members.
+func generateSessionID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,20 @@
+package tss
+
+import "testing"
+
+func TestGenerateThresholdSignerRequiresPreParams(t *testing.T) {
+	_, err := GenerateThresholdSigner(
+		"group",
+		MemberID("member-1"),
+		[]MemberID{MemberID("member-1"), MemberID("member-2")},
+		1,
+		"session",
+		Secp256k1,
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected an error when tss pre-params are not provided")
+	}
+}